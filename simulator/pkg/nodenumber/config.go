@@ -0,0 +1,115 @@
+package nodenumber
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Defaults applied to NodeNumberArgs fields left unset by the operator.
+const (
+	DefaultLogEndpoint          = "https://p9-scheduler-plugins.vercel.app/log"
+	DefaultRenewableWeight      = 0.5
+	DefaultBatteryWeight        = 0.5
+	DefaultSigmoidSteepness     = 0.05
+	DefaultBatteryBaseline      = 20.0
+	DefaultScoringModuleTimeout = 100 * time.Millisecond
+)
+
+// NodeNumberArgs is arguments for node number plugin.
+//
+//nolint:revive
+type NodeNumberArgs struct {
+	metav1.TypeMeta
+
+	Reverse bool `json:"reverse"`
+
+	// DataEndpoint is the telemetry API LocationData is fetched from. Defaults to the
+	// p9-scheduler-plugins telemetry service.
+	DataEndpoint string `json:"dataEndpoint,omitempty"`
+	// LogEndpoint is where PostBind reports bind events to. Defaults to DefaultLogEndpoint.
+	LogEndpoint string `json:"logEndpoint,omitempty"`
+	// RefreshInterval controls how often the LocationData cache is refreshed in the
+	// background. Defaults to 30s.
+	RefreshInterval metav1.Duration `json:"refreshInterval,omitempty"`
+	// StaleAfter is how long the LocationData cache may go without a successful refresh
+	// before Score and Filter treat it as stale. Defaults to 3x RefreshInterval.
+	StaleAfter metav1.Duration `json:"staleAfter,omitempty"`
+	// RenewableWeight is the weight given to the renewable-output score in the final
+	// blend. Defaults to DefaultRenewableWeight.
+	RenewableWeight float64 `json:"renewableWeight,omitempty"`
+	// BatteryWeight is the weight given to the battery-charge score in the final blend.
+	// Defaults to DefaultBatteryWeight.
+	BatteryWeight float64 `json:"batteryWeight,omitempty"`
+	// SigmoidSteepness controls how sharply the renewable score saturates towards 0 or
+	// 100. Defaults to DefaultSigmoidSteepness.
+	SigmoidSteepness float64 `json:"sigmoidSteepness,omitempty"`
+	// BatteryBaseline is subtracted from BatteryCharge before it is folded into the
+	// score. Defaults to DefaultBatteryBaseline.
+	BatteryBaseline float64 `json:"batteryBaseline,omitempty"`
+	// MinBatteryCharge is the minimum BatteryCharge a node's location must report for
+	// Filter to consider it schedulable. Defaults to 0 (no minimum enforced).
+	MinBatteryCharge float64 `json:"minBatteryCharge,omitempty"`
+	// ScoringModule is the path to a WebAssembly module exporting a score function. When
+	// set, Score calls into it instead of the built-in renewable+battery formula.
+	ScoringModule string `json:"scoringModule,omitempty"`
+	// ScoringModuleTimeout bounds each call into ScoringModule, so a slow or buggy
+	// module cannot stall scheduling. Defaults to DefaultScoringModuleTimeout.
+	ScoringModuleTimeout metav1.Duration `json:"scoringModuleTimeout,omitempty"`
+}
+
+// SetDefaults fills in zero-valued fields of args with the plugin's defaults.
+func SetDefaults(args *NodeNumberArgs) {
+	if args.DataEndpoint == "" {
+		args.DataEndpoint = defaultDataEndpoint
+	}
+	if args.LogEndpoint == "" {
+		args.LogEndpoint = DefaultLogEndpoint
+	}
+	if args.RefreshInterval.Duration == 0 {
+		args.RefreshInterval = metav1.Duration{Duration: defaultRefreshInterval}
+	}
+	if args.StaleAfter.Duration == 0 {
+		args.StaleAfter = metav1.Duration{Duration: args.RefreshInterval.Duration * staleAfterFactor}
+	}
+	if args.RenewableWeight == 0 && args.BatteryWeight == 0 {
+		args.RenewableWeight = DefaultRenewableWeight
+		args.BatteryWeight = DefaultBatteryWeight
+	}
+	if args.SigmoidSteepness == 0 {
+		args.SigmoidSteepness = DefaultSigmoidSteepness
+	}
+	if args.BatteryBaseline == 0 {
+		args.BatteryBaseline = DefaultBatteryBaseline
+	}
+	if args.ScoringModuleTimeout.Duration == 0 {
+		args.ScoringModuleTimeout = metav1.Duration{Duration: DefaultScoringModuleTimeout}
+	}
+}
+
+// Validate checks that args holds a usable configuration.
+func Validate(args *NodeNumberArgs) error {
+	if args.RenewableWeight+args.BatteryWeight <= 0 {
+		return fmt.Errorf("renewableWeight + batteryWeight must be > 0, got %v + %v", args.RenewableWeight, args.BatteryWeight)
+	}
+	if args.RefreshInterval.Duration <= 0 {
+		return fmt.Errorf("refreshInterval must be > 0, got %v", args.RefreshInterval.Duration)
+	}
+	if args.StaleAfter.Duration <= 0 {
+		return fmt.Errorf("staleAfter must be > 0, got %v", args.StaleAfter.Duration)
+	}
+	if args.MinBatteryCharge < 0 {
+		return fmt.Errorf("minBatteryCharge must be >= 0, got %v", args.MinBatteryCharge)
+	}
+	if args.ScoringModule != "" && args.ScoringModuleTimeout.Duration <= 0 {
+		return fmt.Errorf("scoringModuleTimeout must be > 0 when scoringModule is set, got %v", args.ScoringModuleTimeout.Duration)
+	}
+	for name, endpoint := range map[string]string{"dataEndpoint": args.DataEndpoint, "logEndpoint": args.LogEndpoint} {
+		if _, err := url.ParseRequestURI(endpoint); err != nil {
+			return fmt.Errorf("%s is not a valid URL: %w", name, err)
+		}
+	}
+	return nil
+}
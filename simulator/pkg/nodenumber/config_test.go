@@ -0,0 +1,131 @@
+package nodenumber
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetDefaults(t *testing.T) {
+	args := NodeNumberArgs{}
+	SetDefaults(&args)
+
+	if args.DataEndpoint == "" {
+		t.Errorf("expected DataEndpoint to be defaulted")
+	}
+	if args.LogEndpoint != DefaultLogEndpoint {
+		t.Errorf("LogEndpoint = %q, want %q", args.LogEndpoint, DefaultLogEndpoint)
+	}
+	if args.RefreshInterval.Duration != defaultRefreshInterval {
+		t.Errorf("RefreshInterval = %v, want %v", args.RefreshInterval.Duration, defaultRefreshInterval)
+	}
+	if want := defaultRefreshInterval * staleAfterFactor; args.StaleAfter.Duration != want {
+		t.Errorf("StaleAfter = %v, want %v", args.StaleAfter.Duration, want)
+	}
+	if args.RenewableWeight != DefaultRenewableWeight || args.BatteryWeight != DefaultBatteryWeight {
+		t.Errorf("weights = %v/%v, want %v/%v", args.RenewableWeight, args.BatteryWeight, DefaultRenewableWeight, DefaultBatteryWeight)
+	}
+	if args.SigmoidSteepness != DefaultSigmoidSteepness {
+		t.Errorf("SigmoidSteepness = %v, want %v", args.SigmoidSteepness, DefaultSigmoidSteepness)
+	}
+	if args.BatteryBaseline != DefaultBatteryBaseline {
+		t.Errorf("BatteryBaseline = %v, want %v", args.BatteryBaseline, DefaultBatteryBaseline)
+	}
+}
+
+func TestSetDefaults_PreservesExplicitWeights(t *testing.T) {
+	args := NodeNumberArgs{RenewableWeight: 0.8, BatteryWeight: 0.2}
+	SetDefaults(&args)
+
+	if args.RenewableWeight != 0.8 || args.BatteryWeight != 0.2 {
+		t.Errorf("weights = %v/%v, want explicit values preserved", args.RenewableWeight, args.BatteryWeight)
+	}
+}
+
+func TestSetDefaults_PreservesExplicitStaleAfter(t *testing.T) {
+	args := NodeNumberArgs{RefreshInterval: metav1.Duration{Duration: time.Minute}, StaleAfter: metav1.Duration{Duration: time.Hour}}
+	SetDefaults(&args)
+
+	if args.StaleAfter.Duration != time.Hour {
+		t.Errorf("StaleAfter = %v, want explicit value preserved", args.StaleAfter.Duration)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		args    NodeNumberArgs
+		wantErr bool
+	}{
+		"valid": {
+			args: NodeNumberArgs{
+				DataEndpoint:    "https://example.com/data",
+				LogEndpoint:     "https://example.com/log",
+				RefreshInterval: metav1.Duration{Duration: time.Second},
+				StaleAfter:      metav1.Duration{Duration: 3 * time.Second},
+				RenewableWeight: 0.5,
+				BatteryWeight:   0.5,
+			},
+			wantErr: false,
+		},
+		"non-positive stale after": {
+			args: NodeNumberArgs{
+				DataEndpoint:    "https://example.com/data",
+				LogEndpoint:     "https://example.com/log",
+				RefreshInterval: metav1.Duration{Duration: time.Second},
+				RenewableWeight: 0.5,
+				BatteryWeight:   0.5,
+			},
+			wantErr: true,
+		},
+		"zero weights": {
+			args: NodeNumberArgs{
+				DataEndpoint:    "https://example.com/data",
+				LogEndpoint:     "https://example.com/log",
+				RefreshInterval: metav1.Duration{Duration: time.Second},
+			},
+			wantErr: true,
+		},
+		"non-positive refresh interval": {
+			args: NodeNumberArgs{
+				DataEndpoint:    "https://example.com/data",
+				LogEndpoint:     "https://example.com/log",
+				RenewableWeight: 0.5,
+				BatteryWeight:   0.5,
+			},
+			wantErr: true,
+		},
+		"unparseable endpoint": {
+			args: NodeNumberArgs{
+				DataEndpoint:    "://not-a-url",
+				LogEndpoint:     "https://example.com/log",
+				RefreshInterval: metav1.Duration{Duration: time.Second},
+				StaleAfter:      metav1.Duration{Duration: 3 * time.Second},
+				RenewableWeight: 0.5,
+				BatteryWeight:   0.5,
+			},
+			wantErr: true,
+		},
+		"scoring module without a positive timeout": {
+			args: NodeNumberArgs{
+				DataEndpoint:    "https://example.com/data",
+				LogEndpoint:     "https://example.com/log",
+				RefreshInterval: metav1.Duration{Duration: time.Second},
+				StaleAfter:      metav1.Duration{Duration: 3 * time.Second},
+				RenewableWeight: 0.5,
+				BatteryWeight:   0.5,
+				ScoringModule:   "score.wasm",
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(&tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
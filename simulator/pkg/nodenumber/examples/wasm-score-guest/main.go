@@ -0,0 +1,71 @@
+// Command wasm-score-guest is a sample TinyGo guest module for the NodeNumber plugin's
+// ScoringModule extension point. It reimplements the plugin's built-in renewable +
+// battery formula, purely to document the expected ABI.
+//
+// Build with:
+//
+//	tinygo build -o score.wasm -target=wasi ./examples/wasm-score-guest
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"unsafe"
+)
+
+type locationData struct {
+	BatteryCharge   float64 `json:"Battery_charge"`
+	RenewableOutput float64 `json:"Renewable_output"`
+	PrimaryLoad     float64 `json:"Primary_load"`
+	UnmetLoad       float64 `json:"Unmet_load"`
+}
+
+// These mirror the plugin's DefaultRenewableWeight, DefaultBatteryWeight,
+// DefaultSigmoidSteepness and DefaultBatteryBaseline.
+const (
+	renewableWeight  = 0.5
+	batteryWeight    = 0.5
+	sigmoidSteepness = 0.05
+	batteryBaseline  = 20.0
+)
+
+//export score
+func score(podPtr, nodePtr, locationPtr uint32) int64 {
+	// The sample formula only needs the location telemetry; pod and node are still
+	// read to exercise the ABI the same way a real module would.
+	_ = readBuf(podPtr)
+	_ = readBuf(nodePtr)
+
+	var loc locationData
+	if err := json.Unmarshal(readBuf(locationPtr), &loc); err != nil {
+		return 0
+	}
+
+	var renewDiff float64
+	if loc.PrimaryLoad > 0 {
+		renewDiff = (loc.RenewableOutput - loc.PrimaryLoad) / loc.PrimaryLoad
+	}
+	renewScore := 100 / (1.0 + math.Pow(math.E, -sigmoidSteepness*100*renewDiff))
+
+	return int64(math.Round(renewScore*renewableWeight + (loc.BatteryCharge-batteryBaseline)*batteryWeight))
+}
+
+//export guest_alloc
+func guestAlloc(size uint32) uint32 {
+	buf := make([]byte, size)
+	return uint32(uintptr(unsafe.Pointer(&buf[0])))
+}
+
+//export guest_free
+func guestFree(ptr uint32) {
+	// TinyGo's GC reclaims guest_alloc'd buffers on its own; this export exists only
+	// because the host ABI requires it.
+}
+
+func readBuf(ptr uint32) []byte {
+	length := *(*uint32)(unsafe.Pointer(uintptr(ptr)))
+	data := unsafe.Pointer(uintptr(ptr) + 4)
+	return unsafe.Slice((*byte)(data), length)
+}
+
+func main() {}
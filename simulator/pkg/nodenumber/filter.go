@@ -0,0 +1,45 @@
+package nodenumber
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// locationLabel is the node label Filter and Score use to look up LocationData.
+const locationLabel = "location"
+
+// Filter rejects nodes whose location is missing telemetry, has unmet load, or has a
+// battery charge below the configured minimum.
+func (pl *NodeNumber) Filter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if pl.provider.IsStale() {
+		// Mirror Score's neutral-on-stale behavior: a telemetry outage shouldn't brick
+		// scheduling cluster-wide, especially since nothing but a Node event re-triggers
+		// Filter once telemetry recovers.
+		klog.InfoS("location data cache is stale, allowing node", "node", nodeInfo.Node().Name)
+		return nil
+	}
+
+	location, ok := nodeInfo.Node().Labels[locationLabel]
+	if !ok {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, fmt.Sprintf("node has no %q label", locationLabel))
+	}
+
+	locationData, ok := pl.provider.Get(location)
+	if !ok {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, fmt.Sprintf("no telemetry for location %q", location))
+	}
+
+	if locationData.UnmetLoad > 0 {
+		return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("location %q has unmet load %v", location, locationData.UnmetLoad))
+	}
+
+	if locationData.BatteryCharge < pl.args.MinBatteryCharge {
+		return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("location %q battery charge %v is below minimum %v", location, locationData.BatteryCharge, pl.args.MinBatteryCharge))
+	}
+
+	return nil
+}
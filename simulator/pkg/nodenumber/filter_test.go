@@ -0,0 +1,79 @@
+package nodenumber
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func nodeInfoWithLabels(name string, labels map[string]string) *framework.NodeInfo {
+	ni := framework.NewNodeInfo()
+	_ = ni.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}})
+	return ni
+}
+
+func TestFilter(t *testing.T) {
+	pl := &NodeNumber{args: NodeNumberArgs{MinBatteryCharge: 10}}
+	pl.provider = &LocationDataProvider{
+		staleAfter: time.Hour,
+		lastFetch:  time.Now(),
+		data: map[string]LocationData{
+			"us-east": {Location: "us-east", BatteryCharge: 50, UnmetLoad: 0},
+			"us-west": {Location: "us-west", BatteryCharge: 5, UnmetLoad: 0},
+			"eu-west": {Location: "eu-west", BatteryCharge: 50, UnmetLoad: 3},
+		},
+	}
+
+	tests := map[string]struct {
+		nodeInfo *framework.NodeInfo
+		wantCode framework.Code
+	}{
+		"healthy location is schedulable": {
+			nodeInfo: nodeInfoWithLabels("n1", map[string]string{"location": "us-east"}),
+			wantCode: framework.Success,
+		},
+		"missing location label is unresolvable": {
+			nodeInfo: nodeInfoWithLabels("n2", nil),
+			wantCode: framework.UnschedulableAndUnresolvable,
+		},
+		"unknown location is unresolvable": {
+			nodeInfo: nodeInfoWithLabels("n3", map[string]string{"location": "mars"}),
+			wantCode: framework.UnschedulableAndUnresolvable,
+		},
+		"unmet load is unschedulable": {
+			nodeInfo: nodeInfoWithLabels("n4", map[string]string{"location": "eu-west"}),
+			wantCode: framework.Unschedulable,
+		},
+		"battery below minimum is unschedulable": {
+			nodeInfo: nodeInfoWithLabels("n5", map[string]string{"location": "us-west"}),
+			wantCode: framework.Unschedulable,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := pl.Filter(context.Background(), nil, &v1.Pod{}, tt.nodeInfo)
+			gotCode := framework.Success
+			if status != nil {
+				gotCode = status.Code()
+			}
+			if gotCode != tt.wantCode {
+				t.Errorf("Filter() code = %v, want %v", gotCode, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestFilter_AllowsOnStaleCache(t *testing.T) {
+	pl := &NodeNumber{args: NodeNumberArgs{MinBatteryCharge: 10}}
+	pl.provider = &LocationDataProvider{staleAfter: time.Hour}
+
+	status := pl.Filter(context.Background(), nil, &v1.Pod{}, nodeInfoWithLabels("n1", map[string]string{"location": "mars"}))
+	if status != nil {
+		t.Errorf("Filter() status = %v, want nil (allowed) on a stale/cold cache", status)
+	}
+}
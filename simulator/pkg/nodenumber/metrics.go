@@ -0,0 +1,65 @@
+package nodenumber
+
+import (
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+// metricsSubsystem is the Prometheus subsystem all of the plugin's metrics are
+// registered under, exposed through the standard kube-scheduler /metrics handler.
+const metricsSubsystem = "nodenumber"
+
+var (
+	apiRequestsTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "api_requests_total",
+			Help:           "Total LocationData telemetry API requests made by the NodeNumber plugin, by result.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"result"},
+	)
+
+	locationDataAgeSeconds = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "location_data_age_seconds",
+			Help:           "Age of the cached LocationData for a location, in seconds, as of its last access.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"location"},
+	)
+
+	scoreDurationSeconds = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "score_duration_seconds",
+			Help:           "Duration of the NodeNumber plugin's Score calls, in seconds.",
+			Buckets:        metrics.DefBuckets,
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	lastScore = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "last_score",
+			Help:           "Most recent raw (pre-normalization) score computed for a location.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"location"},
+	)
+
+	reporterDroppedTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "reporter_dropped_events_total",
+			Help:           "Total PostBind events dropped because the BindReporter's queue was full.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+)
+
+func init() {
+	legacyregistry.MustRegister(apiRequestsTotal, locationDataAgeSeconds, scoreDurationSeconds, lastScore, reporterDroppedTotal)
+}
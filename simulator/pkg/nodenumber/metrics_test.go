@@ -0,0 +1,39 @@
+package nodenumber
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestApiRequestsTotal(t *testing.T) {
+	apiRequestsTotal.Reset()
+	apiRequestsTotal.WithLabelValues("success").Inc()
+	apiRequestsTotal.WithLabelValues("error").Inc()
+	apiRequestsTotal.WithLabelValues("error").Inc()
+
+	want := `
+		# HELP nodenumber_api_requests_total [ALPHA] Total LocationData telemetry API requests made by the NodeNumber plugin, by result.
+		# TYPE nodenumber_api_requests_total counter
+		nodenumber_api_requests_total{result="error"} 2
+		nodenumber_api_requests_total{result="success"} 1
+	`
+	if err := testutil.CollectAndCompare(apiRequestsTotal, strings.NewReader(want), "nodenumber_api_requests_total"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLastScore(t *testing.T) {
+	lastScore.Reset()
+	lastScore.WithLabelValues("us-east").Set(42.5)
+
+	want := `
+		# HELP nodenumber_last_score [ALPHA] Most recent raw (pre-normalization) score computed for a location.
+		# TYPE nodenumber_last_score gauge
+		nodenumber_last_score{location="us-east"} 42.5
+	`
+	if err := testutil.CollectAndCompare(lastScore, strings.NewReader(want), "nodenumber_last_score"); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,20 +1,13 @@
 package nodenumber
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
-	"io"
-	"math"
-	"net/http"
 	"slices"
 	"strconv"
 
 	"golang.org/x/xerrors"
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
@@ -35,12 +28,22 @@ type NodeNumber struct {
 	// For example:
 	// When schedule a pod named Pod1, a Node named Node1 gets a lower score than a node named Node9.
 	reverse bool
+	// provider caches LocationData in the background so Score never blocks on network I/O.
+	provider *LocationDataProvider
+	// args holds the resolved (defaulted and validated) plugin configuration.
+	args NodeNumberArgs
+	// wasmScorer, when non-nil, overrides the built-in scoring formula with args.ScoringModule.
+	wasmScorer *wasmScorer
+	// reporter batches and sends PostBind events without blocking the binding hot path.
+	reporter *BindReporter
 }
 
 var (
-	_ framework.ScorePlugin    = &NodeNumber{}
-	_ framework.PreScorePlugin = &NodeNumber{}
-	_ framework.PostBindPlugin = &NodeNumber{}
+	_ framework.ScorePlugin     = &NodeNumber{}
+	_ framework.PreScorePlugin  = &NodeNumber{}
+	_ framework.PostBindPlugin  = &NodeNumber{}
+	_ framework.FilterPlugin    = &NodeNumber{}
+	_ framework.ScoreExtensions = &NodeNumber{}
 )
 
 const (
@@ -85,66 +88,34 @@ func (pl *NodeNumber) PreScore(ctx context.Context, state *framework.CycleState,
 
 func (pl *NodeNumber) EventsToRegister() []framework.ClusterEvent {
 	return []framework.ClusterEvent{
-		{Resource: framework.Node, ActionType: framework.Add},
+		{Resource: framework.Node, ActionType: framework.Add | framework.UpdateNodeLabel},
 	}
 }
 
 var ErrNotExpectedPreScoreState = errors.New("unexpected pre score state")
 
-type NodeRequest struct {
-	Node string `json:"node"`
-}
-
 func (pl *NodeNumber) PostBind(ctx context.Context, state *framework.CycleState, p *v1.Pod, nodeName string) {
-	// Data to send
-	data := NodeRequest{Node: nodeName}
-
-	// Convert data to JSON
-	jsonData, _ := json.Marshal(data)
-
-	// Make the POST request
-	resp, err := http.Post("https://p9-scheduler-plugins.vercel.app/log", "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		fmt.Println("Error:", err)
-		return
-	}
-	defer resp.Body.Close()
-	// Remove this
-	http.Get("https://eojwg1nx782egtx.m.pipedream.net")
+	pl.reporter.Report(NodeRequest{Node: nodeName})
 }
 
-// Score invoked at the score extension point.
-func (pl *NodeNumber) Score(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
-
-	apiData, apierr := GetData()
-
-	if apierr != nil {
-		klog.InfoS("api fail")
-		return 22, nil
+// GetLocationBatteryCharge returns the BatteryCharge reported for loc, and whether loc
+// was found in data.
+func GetLocationBatteryCharge(loc string, data []LocationData) (float64, bool) {
+	d, ok := GetLocationData(loc, data)
+	if !ok {
+		return 0, false
 	}
-
-	nodeList, _ := pl.fh.SnapshotSharedLister().NodeInfos().List()
-	idx := slices.IndexFunc(nodeList, func(n *framework.NodeInfo) bool { return n.Node().Name == nodeName })
-	location := nodeList[idx].Node().Labels["location"]
-
-	LocationData := GetLocationData(location, apiData)
-
-	renewDiff := (LocationData.RenewableOutput - LocationData.PrimaryLoad) / LocationData.PrimaryLoad
-
-	renewScore := 100 / (1.0 + math.Pow(math.E, (-0.05*100*renewDiff)))
-
-	return int64(math.Round(renewScore)*0.5 + (math.Round(LocationData.BatteryCharge)-20)*0.5), nil
-
-}
-
-func GetLocationBatteryCharge(loc string, data []LocationData) float64 {
-	idx := slices.IndexFunc(data, func(d LocationData) bool { return d.Location == loc })
-	return data[idx].BatteryCharge
+	return d.BatteryCharge, true
 }
 
-func GetLocationData(loc string, data []LocationData) LocationData {
+// GetLocationData returns the LocationData reported for loc, and whether loc was found
+// in data.
+func GetLocationData(loc string, data []LocationData) (LocationData, bool) {
 	idx := slices.IndexFunc(data, func(d LocationData) bool { return d.Location == loc })
-	return data[idx]
+	if idx == -1 {
+		return LocationData{}, false
+	}
+	return data[idx], true
 }
 
 type LocationData struct {
@@ -156,34 +127,6 @@ type LocationData struct {
 	Location        string  `json:"Location"`
 }
 
-func GetData() ([]LocationData, error) {
-	resp, err := http.Get("https://p9-scheduler-plugins.vercel.app/data")
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var data []LocationData
-	err = json.Unmarshal(body, &data)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return data, nil
-}
-
-// ScoreExtensions of the Score plugin.
-func (pl *NodeNumber) ScoreExtensions() framework.ScoreExtensions {
-	return nil
-}
-
 // New initializes a new plugin and returns it.
 func New(ctx context.Context, arg runtime.Object, h framework.Handle) (framework.Plugin, error) {
 	typedArg := NodeNumberArgs{Reverse: false}
@@ -194,14 +137,28 @@ func New(ctx context.Context, arg runtime.Object, h framework.Handle) (framework
 		}
 		klog.Info("NodeNumberArgs is successfully applied")
 	}
-	return &NodeNumber{fh: h, reverse: typedArg.Reverse}, nil
-}
 
-// NodeNumberArgs is arguments for node number plugin.
-//
-//nolint:revive
-type NodeNumberArgs struct {
-	metav1.TypeMeta
+	SetDefaults(&typedArg)
+	if err := Validate(&typedArg); err != nil {
+		return nil, xerrors.Errorf("validate NodeNumberArgs: %w", err)
+	}
+
+	provider := NewLocationDataProvider(ctx, typedArg.DataEndpoint, typedArg.RefreshInterval.Duration, typedArg.StaleAfter.Duration)
+
+	var scorer *wasmScorer
+	if typedArg.ScoringModule != "" {
+		var err error
+		scorer, err = newWasmScorer(ctx, typedArg.ScoringModule, typedArg.ScoringModuleTimeout.Duration)
+		if err != nil {
+			return nil, xerrors.Errorf("load scoring module %q: %w", typedArg.ScoringModule, err)
+		}
+		go func() {
+			<-ctx.Done()
+			_ = scorer.Close(context.Background())
+		}()
+	}
+
+	reporter := NewBindReporter(ctx, typedArg.LogEndpoint)
 
-	Reverse bool `json:"reverse"`
+	return &NodeNumber{fh: h, reverse: typedArg.Reverse, provider: provider, args: typedArg, wasmScorer: scorer, reporter: reporter}, nil
 }
@@ -0,0 +1,127 @@
+package nodenumber
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// defaultDataEndpoint is the telemetry API queried for LocationData.
+	defaultDataEndpoint = "https://p9-scheduler-plugins.vercel.app/data"
+	// defaultRefreshInterval is how often the cache is repopulated in the background.
+	defaultRefreshInterval = 30 * time.Second
+	// staleAfterFactor is the default multiple of RefreshInterval used to derive
+	// NodeNumberArgs.StaleAfter when it isn't set explicitly.
+	staleAfterFactor = 3
+)
+
+// LocationDataProvider fetches LocationData from the telemetry endpoint once
+// and then keeps it fresh via a background goroutine, so that Score never has
+// to block on network I/O.
+type LocationDataProvider struct {
+	endpoint        string
+	refreshInterval time.Duration
+	staleAfter      time.Duration
+	httpClient      *http.Client
+
+	mu        sync.RWMutex
+	data      map[string]LocationData
+	lastFetch time.Time
+}
+
+// NewLocationDataProvider starts a background refresher that fetches from endpoint
+// every refreshInterval, and stops it when ctx is done. staleAfter controls how long
+// the cache may go without a successful refresh before IsStale reports true.
+func NewLocationDataProvider(ctx context.Context, endpoint string, refreshInterval, staleAfter time.Duration) *LocationDataProvider {
+	p := &LocationDataProvider{
+		endpoint:        endpoint,
+		refreshInterval: refreshInterval,
+		staleAfter:      staleAfter,
+		httpClient:      &http.Client{Timeout: refreshInterval},
+		data:            map[string]LocationData{},
+	}
+
+	p.refresh(ctx)
+	go p.run(ctx)
+
+	return p
+}
+
+func (p *LocationDataProvider) run(ctx context.Context) {
+	ticker := time.NewTicker(p.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.refresh(ctx)
+		}
+	}
+}
+
+func (p *LocationDataProvider) refresh(ctx context.Context) {
+	data, err := p.fetch(ctx)
+	if err != nil {
+		apiRequestsTotal.WithLabelValues("error").Inc()
+		klog.ErrorS(err, "failed to refresh location data")
+		return
+	}
+	apiRequestsTotal.WithLabelValues("success").Inc()
+
+	byLocation := make(map[string]LocationData, len(data))
+	for _, d := range data {
+		byLocation[d.Location] = d
+	}
+
+	p.mu.Lock()
+	p.data = byLocation
+	p.lastFetch = time.Now()
+	p.mu.Unlock()
+}
+
+func (p *LocationDataProvider) fetch(ctx context.Context) ([]LocationData, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var data []LocationData
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Get returns the cached LocationData for location, and whether it was found.
+func (p *LocationDataProvider) Get(location string) (LocationData, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	d, ok := p.data[location]
+	if ok {
+		locationDataAgeSeconds.WithLabelValues(location).Set(time.Since(p.lastFetch).Seconds())
+	}
+	return d, ok
+}
+
+// IsStale reports whether the cache hasn't been refreshed within staleAfter.
+func (p *LocationDataProvider) IsStale() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.lastFetch.IsZero() || time.Since(p.lastFetch) > p.staleAfter
+}
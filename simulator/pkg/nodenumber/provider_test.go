@@ -0,0 +1,85 @@
+package nodenumber
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLocationDataProvider_Get(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"Location":"us-east","Battery_charge":80,"Renewable_output":120,"Primary_load":100,"Unmet_load":0}]`))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := NewLocationDataProvider(ctx, srv.URL, time.Hour, 3*time.Hour)
+
+	got, ok := p.Get("us-east")
+	if !ok {
+		t.Fatalf("expected us-east to be present in cache")
+	}
+	if got.BatteryCharge != 80 {
+		t.Errorf("BatteryCharge = %v, want 80", got.BatteryCharge)
+	}
+
+	if _, ok := p.Get("unknown"); ok {
+		t.Errorf("expected unknown location to be absent")
+	}
+
+	if p.IsStale() {
+		t.Errorf("provider should not be stale right after a successful fetch")
+	}
+}
+
+func TestLocationDataProvider_RefreshesInBackground(t *testing.T) {
+	var charge int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		charge++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fmt.Sprintf(`[{"Location":"us-east","Battery_charge":%d,"Renewable_output":1,"Primary_load":1,"Unmet_load":0}]`, charge)))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := NewLocationDataProvider(ctx, srv.URL, 10*time.Millisecond, 100*time.Millisecond)
+
+	last, _ := p.Get("us-east")
+	for i := 0; i < 50; i++ {
+		d, _ := p.Get("us-east")
+		if d.BatteryCharge > last.BatteryCharge {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected background refresh to update cached data, stuck at %v", last)
+}
+
+func TestLocationDataProvider_StopsOnContextCancel(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	NewLocationDataProvider(ctx, srv.URL, 5*time.Millisecond, 50*time.Millisecond)
+	cancel()
+
+	afterCancel := requests
+	time.Sleep(30 * time.Millisecond)
+	if requests > afterCancel+1 {
+		t.Errorf("expected no further requests after context cancellation, got %d more", requests-afterCancel)
+	}
+}
+
@@ -0,0 +1,30 @@
+package nodenumber
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// SchemeGroupVersion is the group version NodeNumberArgs is registered under, so it can
+// be decoded straight out of a KubeSchedulerConfiguration the same way built-in plugins'
+// args are.
+var SchemeGroupVersion = schema.GroupVersion{Group: "kubescheduler.config.k8s.io", Version: "v1"}
+
+var (
+	// SchemeBuilder registers NodeNumberArgs, and its defaulting func, with a *runtime.Scheme.
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes, addDefaultingFuncs)
+	// AddToScheme registers NodeNumberArgs with a scheme, e.g. the kube-scheduler's own
+	// config scheme, so operators can supply it under pluginConfig in their
+	// KubeSchedulerConfiguration.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion, &NodeNumberArgs{})
+	return nil
+}
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&NodeNumberArgs{}, func(obj interface{}) { SetDefaults(obj.(*NodeNumberArgs)) })
+	return nil
+}
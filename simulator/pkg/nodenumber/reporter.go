@@ -0,0 +1,148 @@
+package nodenumber
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// reporterQueueSize bounds how many bind events can be buffered before new ones are
+	// dropped rather than blocking PostBind.
+	reporterQueueSize = 256
+	// reporterBatchSize flushes a batch as soon as it reaches this many events.
+	reporterBatchSize = 50
+	// reporterBatchInterval flushes whatever is batched at least this often.
+	reporterBatchInterval = 2 * time.Second
+	// reporterMaxRetries bounds how many times a batch is retried on a 5xx response.
+	reporterMaxRetries = 3
+	// reporterBaseBackoff is the initial delay between retries, doubled after each one.
+	reporterBaseBackoff = 200 * time.Millisecond
+)
+
+// NodeRequest is a single bind event reported to a BindReporter's endpoint.
+type NodeRequest struct {
+	Node string `json:"node"`
+}
+
+// BindReporter batches NodeRequest bind events and POSTs them to an endpoint from a
+// single background worker, so PostBind never blocks the binding hot path on network
+// I/O, and a slow or failing endpoint never backs up scheduling.
+type BindReporter struct {
+	endpoint   string
+	httpClient *http.Client
+	events     chan NodeRequest
+}
+
+// NewBindReporter starts a worker that flushes batched events to endpoint every
+// reporterBatchInterval, or every reporterBatchSize events, whichever comes first, and
+// stops when ctx is done.
+func NewBindReporter(ctx context.Context, endpoint string) *BindReporter {
+	r := &BindReporter{
+		endpoint:   endpoint,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		events:     make(chan NodeRequest, reporterQueueSize),
+	}
+
+	go r.run(ctx)
+
+	return r
+}
+
+// Report enqueues a bind event. If the queue is full, the event is dropped and
+// reporterDroppedTotal is incremented instead of blocking the caller.
+func (r *BindReporter) Report(event NodeRequest) {
+	select {
+	case r.events <- event:
+	default:
+		reporterDroppedTotal.Inc()
+		klog.InfoS("bind reporter queue full, dropping event", "node", event.Node)
+	}
+}
+
+func (r *BindReporter) run(ctx context.Context) {
+	ticker := time.NewTicker(reporterBatchInterval)
+	defer ticker.Stop()
+
+	batch := make([]NodeRequest, 0, reporterBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		r.send(ctx, batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			flush()
+			return
+		case e := <-r.events:
+			batch = append(batch, e)
+			if len(batch) >= reporterBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// send POSTs batch as a single JSON array, retrying on a 5xx response with exponential
+// backoff up to reporterMaxRetries times before giving up on it.
+func (r *BindReporter) send(ctx context.Context, batch []NodeRequest) {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		klog.ErrorS(err, "failed to marshal bind event batch")
+		return
+	}
+
+	backoff := reporterBaseBackoff
+	for attempt := 0; attempt <= reporterMaxRetries; attempt++ {
+		if r.sendOnce(ctx, body) {
+			return
+		}
+
+		if attempt == reporterMaxRetries {
+			klog.InfoS("giving up on bind report batch after retries", "events", len(batch))
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// sendOnce makes a single attempt to POST body and reports whether it succeeded (2xx/4xx
+// responses are considered final; only a transport error or 5xx triggers a retry).
+func (r *BindReporter) sendOnce(ctx context.Context, body []byte) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		klog.ErrorS(err, "failed to build bind report request")
+		return false
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		klog.ErrorS(err, "failed to send bind report batch")
+		return false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		klog.InfoS("bind report batch rejected by server, retrying", "status", resp.StatusCode)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,81 @@
+package nodenumber
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestBindReporter_FlushesOnBatchSize(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := NewBindReporter(ctx, srv.URL)
+	for i := 0; i < reporterBatchSize; i++ {
+		r.Report(NodeRequest{Node: "node"})
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(&requests) > 0 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected a batch flush once reporterBatchSize events were reported")
+}
+
+func TestBindReporter_RetriesOn5xx(t *testing.T) {
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		_, _ = io.ReadAll(r.Body)
+		if n < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := NewBindReporter(ctx, srv.URL)
+	r.Report(NodeRequest{Node: "node"})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(&attempts) >= 2 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected a retry after a 5xx response, got %d attempts", atomic.LoadInt32(&attempts))
+}
+
+func TestBindReporter_DropsWhenQueueFull(t *testing.T) {
+	r := &BindReporter{events: make(chan NodeRequest, 1)}
+	before := testutil.ToFloat64(reporterDroppedTotal)
+
+	r.Report(NodeRequest{Node: "a"})
+	r.Report(NodeRequest{Node: "b"})
+
+	if got := testutil.ToFloat64(reporterDroppedTotal) - before; got != 1 {
+		t.Errorf("reporterDroppedTotal delta = %v, want 1", got)
+	}
+}
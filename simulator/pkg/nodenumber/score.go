@@ -0,0 +1,158 @@
+package nodenumber
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// scoreState holds the raw (pre-normalization) score computed for a node during Score,
+// so NormalizeScore can min-max scale across the whole candidate list without the
+// precision loss of rounding twice.
+type scoreState struct {
+	raw float64
+}
+
+func (s *scoreState) Clone() framework.StateData {
+	return s
+}
+
+func scoreStateKey(nodeName string) string {
+	return fmt.Sprintf("%s/score/%s", Name, nodeName)
+}
+
+// Score invoked at the score extension point.
+func (pl *NodeNumber) Score(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
+	start := time.Now()
+	defer func() { scoreDurationSeconds.Observe(time.Since(start).Seconds()) }()
+
+	if pl.provider.IsStale() {
+		klog.InfoS("location data cache is stale, returning neutral score", "node", nodeName)
+		return framework.MaxNodeScore / 2, framework.NewStatus(framework.Success, "location data cache is stale")
+	}
+
+	nodeList, _ := pl.fh.SnapshotSharedLister().NodeInfos().List()
+	idx := slices.IndexFunc(nodeList, func(n *framework.NodeInfo) bool { return n.Node().Name == nodeName })
+	if idx == -1 {
+		klog.InfoS("node not found in snapshot, returning neutral score", "node", nodeName)
+		return framework.MaxNodeScore / 2, framework.NewStatus(framework.Success, "node not found in snapshot")
+	}
+	location := nodeList[idx].Node().Labels[locationLabel]
+
+	locationData, ok := pl.provider.Get(location)
+	if !ok {
+		klog.InfoS("no location data for node, returning neutral score", "node", nodeName, "location", location)
+		return framework.MaxNodeScore / 2, framework.NewStatus(framework.Success, "no location data for node's location")
+	}
+
+	raw, err := pl.rawScore(ctx, pod, nodeList[idx].Node(), locationData)
+	if err != nil {
+		klog.ErrorS(err, "scoring module failed, falling back to built-in formula", "node", nodeName)
+		raw = pl.builtinRawScore(locationData)
+	}
+	if math.IsNaN(raw) || math.IsInf(raw, 0) {
+		klog.InfoS("scoring produced a non-finite raw score, returning neutral score", "node", nodeName)
+		return framework.MaxNodeScore / 2, framework.NewStatus(framework.Success, "non-finite raw score")
+	}
+
+	state.Write(scoreStateKey(nodeName), &scoreState{raw: raw})
+	lastScore.WithLabelValues(location).Set(raw)
+
+	return int64(math.Round(raw)), nil
+}
+
+// rawScore computes the raw (pre-normalization) score for a node, using the configured
+// WebAssembly module if any, and the built-in formula otherwise.
+func (pl *NodeNumber) rawScore(ctx context.Context, pod *v1.Pod, node *v1.Node, locationData LocationData) (float64, error) {
+	if pl.wasmScorer == nil {
+		return pl.builtinRawScore(locationData), nil
+	}
+
+	podJSON, err := json.Marshal(pod)
+	if err != nil {
+		return 0, fmt.Errorf("marshal pod: %w", err)
+	}
+	nodeJSON, err := json.Marshal(node)
+	if err != nil {
+		return 0, fmt.Errorf("marshal node: %w", err)
+	}
+	locationJSON, err := json.Marshal(locationData)
+	if err != nil {
+		return 0, fmt.Errorf("marshal location data: %w", err)
+	}
+
+	raw, err := pl.wasmScorer.score(ctx, podJSON, nodeJSON, locationJSON)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(raw), nil
+}
+
+// builtinRawScore is the plugin's default renewable+battery formula.
+func (pl *NodeNumber) builtinRawScore(locationData LocationData) float64 {
+	var renewDiff float64
+	if locationData.PrimaryLoad > 0 {
+		renewDiff = (locationData.RenewableOutput - locationData.PrimaryLoad) / locationData.PrimaryLoad
+	}
+	renewScore := 100 / (1.0 + math.Pow(math.E, (-pl.args.SigmoidSteepness*100*renewDiff)))
+	batteryScore := locationData.BatteryCharge - pl.args.BatteryBaseline
+
+	return renewScore*pl.args.RenewableWeight + batteryScore*pl.args.BatteryWeight
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *NodeNumber) ScoreExtensions() framework.ScoreExtensions {
+	return pl
+}
+
+// NormalizeScore min-max scales the raw scores computed in Score across the candidate
+// node list into [0, framework.MaxNodeScore]. Score's own return value can be negative
+// or exceed MaxNodeScore (it's only ever correct relative to other nodes in the same
+// cycle), so the framework-visible score is always derived here instead.
+func (pl *NodeNumber) NormalizeScore(ctx context.Context, state *framework.CycleState, pod *v1.Pod, scores framework.NodeScoreList) *framework.Status {
+	raws := make([]float64, len(scores))
+	minRaw, maxRaw := math.Inf(1), math.Inf(-1)
+
+	for i, nodeScore := range scores {
+		data, err := state.Read(scoreStateKey(nodeScore.Name))
+		if err != nil {
+			// Score returned a neutral score directly (stale cache or missing
+			// telemetry); leave it untouched and exclude it from min-max scaling.
+			raws[i] = math.NaN()
+			continue
+		}
+
+		raw := data.(*scoreState).raw
+		if math.IsNaN(raw) || math.IsInf(raw, 0) {
+			// A buggy scoring module (or a 0/0 edge case) produced a non-finite raw
+			// score; exclude it from min-max scaling like a neutral score instead of
+			// letting it poison minRaw/maxRaw for every other node in the cycle.
+			raws[i] = math.NaN()
+			continue
+		}
+		raws[i] = raw
+		minRaw = math.Min(minRaw, raw)
+		maxRaw = math.Max(maxRaw, raw)
+	}
+
+	for i := range scores {
+		if math.IsNaN(raws[i]) {
+			continue
+		}
+		if maxRaw == minRaw {
+			scores[i].Score = framework.MaxNodeScore
+			continue
+		}
+		scores[i].Score = int64(math.Round((raws[i] - minRaw) / (maxRaw - minRaw) * float64(framework.MaxNodeScore)))
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package nodenumber
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+func writeRawScore(t *testing.T, state *framework.CycleState, nodeName string, raw float64) {
+	t.Helper()
+	state.Write(scoreStateKey(nodeName), &scoreState{raw: raw})
+}
+
+func TestNormalizeScore_MinMaxScales(t *testing.T) {
+	pl := &NodeNumber{}
+	state := framework.NewCycleState()
+
+	writeRawScore(t, state, "n1", -10)
+	writeRawScore(t, state, "n2", 0)
+	writeRawScore(t, state, "n3", 40)
+
+	scores := framework.NodeScoreList{
+		{Name: "n1", Score: 0},
+		{Name: "n2", Score: 0},
+		{Name: "n3", Score: 0},
+	}
+
+	if status := pl.NormalizeScore(context.Background(), state, nil, scores); !status.IsSuccess() {
+		t.Fatalf("NormalizeScore() status = %v, want success", status)
+	}
+
+	if scores[0].Score != 0 {
+		t.Errorf("min raw score = %d, want 0", scores[0].Score)
+	}
+	if scores[2].Score != framework.MaxNodeScore {
+		t.Errorf("max raw score = %d, want %d", scores[2].Score, framework.MaxNodeScore)
+	}
+	if scores[1].Score <= scores[0].Score || scores[1].Score >= scores[2].Score {
+		t.Errorf("middle raw score %d should be strictly between min and max", scores[1].Score)
+	}
+}
+
+func TestNormalizeScore_SingleNodeGetsMaxScore(t *testing.T) {
+	pl := &NodeNumber{}
+	state := framework.NewCycleState()
+	writeRawScore(t, state, "n1", 7.3)
+
+	scores := framework.NodeScoreList{{Name: "n1", Score: 0}}
+
+	if status := pl.NormalizeScore(context.Background(), state, nil, scores); !status.IsSuccess() {
+		t.Fatalf("NormalizeScore() status = %v, want success", status)
+	}
+	if scores[0].Score != framework.MaxNodeScore {
+		t.Errorf("Score = %d, want %d", scores[0].Score, framework.MaxNodeScore)
+	}
+}
+
+func TestBuiltinRawScore_ZeroPrimaryLoadDoesNotProduceNaN(t *testing.T) {
+	pl := &NodeNumber{args: NodeNumberArgs{RenewableWeight: 0.5, BatteryWeight: 0.5, SigmoidSteepness: DefaultSigmoidSteepness, BatteryBaseline: DefaultBatteryBaseline}}
+
+	raw := pl.builtinRawScore(LocationData{PrimaryLoad: 0, RenewableOutput: 0, BatteryCharge: 50})
+	if math.IsNaN(raw) || math.IsInf(raw, 0) {
+		t.Errorf("builtinRawScore() = %v, want a finite value when PrimaryLoad is 0", raw)
+	}
+}
+
+func TestNormalizeScore_ExcludesNonFiniteRaw(t *testing.T) {
+	pl := &NodeNumber{}
+	state := framework.NewCycleState()
+	writeRawScore(t, state, "n1", math.NaN())
+	writeRawScore(t, state, "n2", 10)
+
+	scores := framework.NodeScoreList{
+		{Name: "n1", Score: 0},
+		{Name: "n2", Score: 0},
+	}
+
+	if status := pl.NormalizeScore(context.Background(), state, nil, scores); !status.IsSuccess() {
+		t.Fatalf("NormalizeScore() status = %v, want success", status)
+	}
+	if scores[0].Score != 0 {
+		t.Errorf("non-finite raw score for n1 = %d, want untouched 0", scores[0].Score)
+	}
+	if scores[1].Score != framework.MaxNodeScore {
+		t.Errorf("n2 score = %d, want %d", scores[1].Score, framework.MaxNodeScore)
+	}
+}
+
+func TestNormalizeScore_LeavesNeutralScoresUntouched(t *testing.T) {
+	pl := &NodeNumber{}
+	state := framework.NewCycleState()
+	writeRawScore(t, state, "n1", 10)
+
+	scores := framework.NodeScoreList{
+		{Name: "n1", Score: 0},
+		{Name: "n2", Score: framework.MaxNodeScore / 2},
+	}
+
+	if status := pl.NormalizeScore(context.Background(), state, nil, scores); !status.IsSuccess() {
+		t.Fatalf("NormalizeScore() status = %v, want success", status)
+	}
+	if scores[1].Score != framework.MaxNodeScore/2 {
+		t.Errorf("neutral score for n2 = %d, want untouched %d", scores[1].Score, framework.MaxNodeScore/2)
+	}
+}
@@ -0,0 +1,148 @@
+package nodenumber
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"k8s.io/klog/v2"
+)
+
+// wasmScorer loads a WebAssembly module implementing the scoring formula, so operators
+// can customize scoring without rebuilding the scheduler binary. The guest ABI is a
+// single exported `score(pod_ptr, node_ptr, location_ptr) -> i64` function operating on
+// length-prefixed JSON buffers in the guest's own linear memory, allocated and freed
+// through its exported `guest_alloc`/`guest_free` functions.
+//
+// Score is invoked concurrently across nodes by the framework's parallelizer, but a
+// single wasm instance has one linear memory and a non-reentrant guest allocator, so
+// mu serializes calls into it.
+type wasmScorer struct {
+	runtime  wazero.Runtime
+	module   api.Module
+	scoreFn  api.Function
+	allocFn  api.Function
+	freeFn   api.Function
+	deadline time.Duration
+
+	mu sync.Mutex
+}
+
+// newWasmScorer compiles and instantiates the module at path. deadline bounds every
+// call into the guest, so a slow or malicious module can't stall scheduling.
+func newWasmScorer(ctx context.Context, path string, deadline time.Duration) (*wasmScorer, error) {
+	wasmBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read wasm module %q: %w", path, err)
+	}
+
+	// CloseOnContextDone makes wazero actually abort a running guest call when ctx
+	// expires; without it the context.WithTimeout in score() is merely decorative.
+	r := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCloseOnContextDone(true))
+
+	compiled, err := r.CompileModule(ctx, wasmBytes)
+	if err != nil {
+		r.Close(ctx)
+		return nil, fmt.Errorf("compile wasm module %q: %w", path, err)
+	}
+
+	// The shipped example guest is built with `tinygo build -target=wasi`, which imports
+	// wasi_snapshot_preview1; host functions for it must be instantiated before the guest
+	// module itself.
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, r); err != nil {
+		r.Close(ctx)
+		return nil, fmt.Errorf("instantiate WASI host module: %w", err)
+	}
+
+	mod, err := r.InstantiateModule(ctx, compiled, wazero.NewModuleConfig())
+	if err != nil {
+		r.Close(ctx)
+		return nil, fmt.Errorf("instantiate wasm module %q: %w", path, err)
+	}
+
+	scoreFn := mod.ExportedFunction("score")
+	allocFn := mod.ExportedFunction("guest_alloc")
+	freeFn := mod.ExportedFunction("guest_free")
+	if scoreFn == nil || allocFn == nil || freeFn == nil {
+		r.Close(ctx)
+		return nil, fmt.Errorf("wasm module %q must export score, guest_alloc and guest_free", path)
+	}
+
+	return &wasmScorer{runtime: r, module: mod, scoreFn: scoreFn, allocFn: allocFn, freeFn: freeFn, deadline: deadline}, nil
+}
+
+// Close tears down the wasm runtime. It's called once, when the plugin's own context is
+// done.
+func (w *wasmScorer) Close(ctx context.Context) error {
+	return w.runtime.Close(ctx)
+}
+
+// score marshals pod, node and locationData as length-prefixed JSON into the guest's
+// linear memory and invokes its exported score function, bounded by w.deadline. Calls
+// are serialized with mu since the guest's memory and allocator aren't reentrant.
+func (w *wasmScorer) score(ctx context.Context, podJSON, nodeJSON, locationJSON []byte) (int64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, w.deadline)
+	defer cancel()
+
+	podPtr, err := w.writeBuf(ctx, podJSON)
+	if err != nil {
+		return 0, fmt.Errorf("write pod buffer: %w", err)
+	}
+	defer w.free(ctx, podPtr)
+
+	nodePtr, err := w.writeBuf(ctx, nodeJSON)
+	if err != nil {
+		return 0, fmt.Errorf("write node buffer: %w", err)
+	}
+	defer w.free(ctx, nodePtr)
+
+	locationPtr, err := w.writeBuf(ctx, locationJSON)
+	if err != nil {
+		return 0, fmt.Errorf("write location buffer: %w", err)
+	}
+	defer w.free(ctx, locationPtr)
+
+	results, err := w.scoreFn.Call(ctx, podPtr, nodePtr, locationPtr)
+	if err != nil {
+		return 0, fmt.Errorf("call wasm score function: %w", err)
+	}
+	if len(results) != 1 {
+		return 0, fmt.Errorf("wasm score function returned %d results, want 1", len(results))
+	}
+
+	return int64(results[0]), nil
+}
+
+// writeBuf allocates len(data)+4 bytes of guest memory via guest_alloc, writes a 4-byte
+// little-endian length prefix followed by data, and returns the pointer.
+func (w *wasmScorer) writeBuf(ctx context.Context, data []byte) (uint64, error) {
+	results, err := w.allocFn.Call(ctx, uint64(len(data)+4))
+	if err != nil {
+		return 0, fmt.Errorf("call guest_alloc: %w", err)
+	}
+	ptr := results[0]
+
+	mem := w.module.Memory()
+	if !mem.WriteUint32Le(uint32(ptr), uint32(len(data))) {
+		return 0, fmt.Errorf("write length prefix at 0x%x", ptr)
+	}
+	if !mem.Write(uint32(ptr)+4, data) {
+		return 0, fmt.Errorf("write %d bytes at 0x%x", len(data), ptr)
+	}
+
+	return ptr, nil
+}
+
+func (w *wasmScorer) free(ctx context.Context, ptr uint64) {
+	if _, err := w.freeFn.Call(ctx, ptr); err != nil {
+		klog.ErrorS(err, "failed to free wasm guest buffer", "ptr", ptr)
+	}
+}